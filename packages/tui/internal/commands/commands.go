@@ -0,0 +1,45 @@
+// Package commands defines the set of named actions the TUI can dispatch,
+// decoupling key bindings and menus from the Model code that executes them.
+package commands
+
+// CommandName identifies a dispatchable TUI action.
+type CommandName string
+
+const (
+	// InputSubmitCommand submits the current editor buffer as a new message.
+	InputSubmitCommand CommandName = "input_submit"
+
+	// EditInEditorCommand opens the input buffer (or the selected message,
+	// if any) in $EDITOR for editing outside of the TUI.
+	EditInEditorCommand CommandName = "edit_in_editor"
+
+	// AppSuspendCommand suspends the TUI process (Ctrl+Z), returning
+	// control to the shell until it is resumed with SIGCONT.
+	AppSuspendCommand CommandName = "app_suspend"
+
+	// RetryCommand drops the last assistant reply and re-sends the last
+	// user message as a new turn.
+	RetryCommand CommandName = "retry"
+
+	// ContinueCommand asks the provider to keep streaming into the
+	// existing assistant message instead of starting a new turn.
+	ContinueCommand CommandName = "continue"
+
+	// AttachFileCommand stages the file at Args[0] as an attachment on
+	// the next submit.
+	AttachFileCommand CommandName = "attach_file"
+
+	// AttachClipboardImageCommand stages the image currently on the
+	// system clipboard as an attachment on the next submit.
+	AttachClipboardImageCommand CommandName = "attach_clipboard_image"
+
+	// RemoveAttachmentCommand unstages the attachment at the index given
+	// in Args[0].
+	RemoveAttachmentCommand CommandName = "remove_attachment"
+)
+
+// Command is a single dispatchable action, optionally carrying arguments.
+type Command struct {
+	Name CommandName
+	Args []string
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/commands"
+)
+
+// Attachment is a single file staged to be sent alongside the next prompt,
+// rendered as a list beneath the editor.
+type Attachment struct {
+	Path string
+	MIME string
+	Size int64
+}
+
+// renderAttachments formats the staged attachments as the list shown
+// beneath the editor prompt, one attachment per line.
+func renderAttachments(attachments []Attachment) string {
+	lines := make([]string, len(attachments))
+	for i, a := range attachments {
+		lines[i] = "- " + formatAttachment(a)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatAttachment(a Attachment) string {
+	label := a.Path
+	if a.MIME != "" {
+		label += " (" + a.MIME + ")"
+	}
+	if a.Size > 0 {
+		label += fmt.Sprintf(", %d bytes", a.Size)
+	}
+	return label
+}
+
+func (m Model) executeAttachFile(cmd commands.Command) (tea.Model, tea.Cmd) {
+	if len(cmd.Args) == 0 {
+		return m, nil
+	}
+	m.lastAttachError = m.editor.AddAttachment(cmd.Args[0])
+	return m, nil
+}
+
+func (m Model) executeAttachClipboardImage() (tea.Model, tea.Cmd) {
+	path, err := clipboardImageToTempfile()
+	if err != nil {
+		m.lastAttachError = err
+		return m, nil
+	}
+	m.lastAttachError = m.editor.AddAttachment(path)
+	return m, nil
+}
+
+func (m Model) executeRemoveAttachment(cmd commands.Command) (tea.Model, tea.Cmd) {
+	if len(cmd.Args) == 0 {
+		return m, nil
+	}
+	index, err := strconv.Atoi(cmd.Args[0])
+	if err != nil {
+		return m, nil
+	}
+	m.editor.RemoveAttachment(index)
+	return m, nil
+}
+
+// clipboardImageToTempfile saves the image currently on the system
+// clipboard to a tempfile and returns its path. Reading binary image data
+// off the clipboard requires a platform-specific backend that this tree
+// does not vendor, so it reports an error rather than faking support.
+func clipboardImageToTempfile() (string, error) {
+	return "", errors.New("clipboard image paste is not supported in this build")
+}
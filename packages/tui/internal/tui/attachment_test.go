@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sst/opencode/internal/commands"
+)
+
+func TestAttachFileCommandStagesAttachment(t *testing.T) {
+	stub := &submitStubEditor{}
+	model := Model{editor: stub}
+
+	command := commands.Command{Name: commands.AttachFileCommand, Args: []string{"/tmp/diagram.png"}}
+
+	next, _ := model.executeCommand(command)
+	_ = next.(Model)
+
+	if len(stub.attachments) != 1 || stub.attachments[0].Path != "/tmp/diagram.png" {
+		t.Fatalf("expected attachment to be staged, got %+v", stub.attachments)
+	}
+}
+
+func TestAttachFileCommandSurfacesErrorInView(t *testing.T) {
+	stub := &submitStubEditor{addAttachErr: errors.New("no such file")}
+	model := Model{editor: stub}
+
+	command := commands.Command{Name: commands.AttachFileCommand, Args: []string{"/tmp/missing.png"}}
+
+	next, _ := model.executeCommand(command)
+	result := next.(Model)
+
+	if !strings.Contains(result.View(), "no such file") {
+		t.Fatalf("expected View to surface the attach error, got %q", result.View())
+	}
+}
+
+func TestViewRendersStagedAttachments(t *testing.T) {
+	stub := &submitStubEditor{attachments: []Attachment{
+		{Path: "diagram.png", MIME: "image/png", Size: 2048},
+		{Path: "notes.txt"},
+	}}
+	model := Model{editor: stub}
+
+	view := model.View()
+
+	if !strings.Contains(view, "diagram.png (image/png), 2048 bytes") {
+		t.Fatalf("expected view to list diagram.png with mime and size, got %q", view)
+	}
+	if !strings.Contains(view, "notes.txt") {
+		t.Fatalf("expected view to list notes.txt, got %q", view)
+	}
+}
+
+func TestRemoveAttachmentCommand(t *testing.T) {
+	stub := &submitStubEditor{attachments: []Attachment{{Path: "a"}, {Path: "b"}}}
+	model := Model{editor: stub}
+
+	command := commands.Command{Name: commands.RemoveAttachmentCommand, Args: []string{"0"}}
+
+	next, _ := model.executeCommand(command)
+	_ = next.(Model)
+
+	if len(stub.attachments) != 1 || stub.attachments[0].Path != "b" {
+		t.Fatalf("expected first attachment to be removed, got %+v", stub.attachments)
+	}
+}
+
+func TestInputSubmitDebounceWithAttachmentsClearsThemOnce(t *testing.T) {
+	stub := &submitStubEditor{attachments: []Attachment{{Path: "a"}}}
+	model := Model{editor: stub}
+
+	command := commands.Command{Name: commands.InputSubmitCommand}
+
+	next, _ := model.executeCommand(command)
+	current := next.(Model)
+	if !current.pendingSubmit {
+		t.Fatal("expected pendingSubmit to be true after first submit command")
+	}
+	if len(stub.attachments) != 1 {
+		t.Fatalf("expected attachments to survive the armed first press, got %+v", stub.attachments)
+	}
+
+	final, _ := current.executeCommand(command)
+	result := final.(Model)
+	if stub.submit != 1 {
+		t.Fatalf("expected submit to be called once, got %d", stub.submit)
+	}
+	if result.pendingSubmit {
+		t.Fatal("expected pendingSubmit to be false after second submit command")
+	}
+	if len(stub.attachments) != 0 {
+		t.Fatalf("expected attachments to be cleared exactly once on submit, got %+v", stub.attachments)
+	}
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// editTarget identifies what a tempfile edit round-trip writes its result
+// back to: the input buffer, or a specific message in the viewport.
+type editTarget int
+
+const (
+	editTargetInput editTarget = iota
+	editTargetMessage
+)
+
+// msgTempfileEditorClosed reports the outcome of an EditInExternalEditor
+// round-trip: the tempfile's final contents (if the editor exited cleanly)
+// and which target they should be written back to.
+type msgTempfileEditorClosed struct {
+	target       editTarget
+	messageIndex int
+	content      string
+	err          error
+}
+
+// executeEditInEditor handles commands.EditInEditorCommand: it edits the
+// selected message's raw text if one is selected, otherwise the input
+// buffer, via editInExternalEditorCmd's suspend/exec/resume round-trip.
+func (m Model) executeEditInEditor() (tea.Model, tea.Cmd) {
+	if m.selectedMessage != nil {
+		idx := *m.selectedMessage
+		return m, editInExternalEditorCmd(editTargetMessage, idx, m.messages[idx].Text)
+	}
+
+	return m, editInExternalEditorCmd(editTargetInput, 0, m.editor.Value())
+}
+
+// handleTempfileEditorClosed applies the result of a tempfile edit round-trip
+// to whichever target it was opened for.
+func (m Model) handleTempfileEditorClosed(msg msgTempfileEditorClosed) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, nil
+	}
+
+	switch msg.target {
+	case editTargetInput:
+		m.editor.SetValueWithAttachments(msg.content)
+	case editTargetMessage:
+		m.messages[msg.messageIndex].Text = msg.content
+	}
+
+	return m, nil
+}
+
+// execProcess hands the editor process to bubbletea, which releases the
+// terminal while it runs and calls the callback once it exits.
+var execProcess = tea.ExecProcess
+
+// editInExternalEditorCmd writes content to a tempfile, execs $EDITOR (or
+// $VISUAL, falling back to vi) on it, and reports the tempfile's final
+// contents back as a msgTempfileEditorClosed for target.
+func editInExternalEditorCmd(target editTarget, messageIndex int, content string) tea.Cmd {
+	f, err := os.CreateTemp("", "opencode-edit-*.md")
+	if err != nil {
+		return func() tea.Msg {
+			return msgTempfileEditorClosed{target: target, messageIndex: messageIndex, err: err}
+		}
+	}
+	path := f.Name()
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		os.Remove(path)
+		return func() tea.Msg {
+			return msgTempfileEditorClosed{target: target, messageIndex: messageIndex, err: err}
+		}
+	}
+	f.Close()
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := exec.Command(editor, path)
+	return execProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return msgTempfileEditorClosed{target: target, messageIndex: messageIndex, err: err}
+		}
+
+		edited, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return msgTempfileEditorClosed{target: target, messageIndex: messageIndex, err: readErr}
+		}
+
+		return msgTempfileEditorClosed{target: target, messageIndex: messageIndex, content: string(edited)}
+	})
+}
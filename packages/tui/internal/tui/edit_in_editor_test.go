@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/commands"
+)
+
+// writeFakeEditor installs a throwaway script as $EDITOR that overwrites
+// whatever tempfile it's pointed at with content, so tests can drive the
+// real tempfile-write/exec/read-back round-trip without a real editor. It
+// also swaps execProcess for one that runs the editor synchronously inside
+// the returned tea.Cmd, so calling that Cmd yields the callback's message.
+func writeFakeEditor(t *testing.T, content string) {
+	t.Helper()
+
+	orig := execProcess
+	execProcess = func(c *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+		return func() tea.Msg { return fn(c.Run()) }
+	}
+	t.Cleanup(func() { execProcess = orig })
+
+	path := filepath.Join(t.TempDir(), "fake-editor.sh")
+	script := "#!/bin/sh\nprintf '%s' \"$FAKE_EDITOR_CONTENT\" > \"$1\"\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake editor script: %v", err)
+	}
+
+	t.Setenv("EDITOR", path)
+	t.Setenv("FAKE_EDITOR_CONTENT", content)
+}
+
+func TestEditInEditorRoundTripsInputThroughTempfile(t *testing.T) {
+	writeFakeEditor(t, "edited via external editor")
+
+	stub := &submitStubEditor{value: "original buffer"}
+	model := Model{editor: stub}
+
+	next, cmd := model.executeCommand(commands.Command{Name: commands.EditInEditorCommand})
+	_ = next.(Model)
+	if cmd == nil {
+		t.Fatal("expected a non-nil tea.Cmd")
+	}
+
+	msg, ok := cmd().(msgTempfileEditorClosed)
+	if !ok {
+		t.Fatalf("expected msgTempfileEditorClosed, got %T", cmd())
+	}
+	if msg.err != nil {
+		t.Fatalf("unexpected error round-tripping through the fake editor: %v", msg.err)
+	}
+	if msg.target != editTargetInput {
+		t.Fatalf("expected target editTargetInput, got %v", msg.target)
+	}
+	if msg.content != "edited via external editor" {
+		t.Fatalf("expected content %q, got %q", "edited via external editor", msg.content)
+	}
+
+	final, _ := model.Update(msg)
+	if stub.setValueWithAttachments != "edited via external editor" {
+		t.Fatalf("expected SetValueWithAttachments to receive the edited content, got %q", stub.setValueWithAttachments)
+	}
+	_ = final.(Model)
+}
+
+func TestEditInEditorRoundTripsSelectedMessageThroughTempfile(t *testing.T) {
+	writeFakeEditor(t, "edited message")
+
+	stub := &submitStubEditor{}
+	selected := 1
+	model := Model{
+		editor: stub,
+		messages: []Message{
+			{Role: "user", Text: "first"},
+			{Role: "assistant", Text: "second"},
+		},
+		selectedMessage: &selected,
+	}
+
+	_, cmd := model.executeCommand(commands.Command{Name: commands.EditInEditorCommand})
+	if cmd == nil {
+		t.Fatal("expected a non-nil tea.Cmd")
+	}
+
+	msg, ok := cmd().(msgTempfileEditorClosed)
+	if !ok {
+		t.Fatalf("expected msgTempfileEditorClosed, got %T", cmd())
+	}
+	if msg.target != editTargetMessage || msg.messageIndex != 1 {
+		t.Fatalf("expected target editTargetMessage at index 1, got target=%v index=%d", msg.target, msg.messageIndex)
+	}
+
+	final, _ := model.Update(msg)
+	result := final.(Model)
+	if result.messages[1].Text != "edited message" {
+		t.Fatalf("expected selected message text to be updated, got %q", result.messages[1].Text)
+	}
+}
+
+func TestHandleTempfileEditorClosedUpdatesInput(t *testing.T) {
+	stub := &submitStubEditor{}
+	model := Model{editor: stub}
+
+	next, _ := model.handleTempfileEditorClosed(msgTempfileEditorClosed{
+		target:  editTargetInput,
+		content: "edited text",
+	})
+	_ = next.(Model)
+
+	if stub.setValueWithAttachments != "edited text" {
+		t.Fatalf("expected SetValueWithAttachments to be called with %q, got %q", "edited text", stub.setValueWithAttachments)
+	}
+}
+
+func TestHandleTempfileEditorClosedUpdatesSelectedMessage(t *testing.T) {
+	stub := &submitStubEditor{}
+	model := Model{
+		editor: stub,
+		messages: []Message{
+			{Role: "user", Text: "first"},
+		},
+	}
+
+	next, _ := model.handleTempfileEditorClosed(msgTempfileEditorClosed{
+		target:       editTargetMessage,
+		messageIndex: 0,
+		content:      "edited message",
+	})
+	result := next.(Model)
+
+	if result.messages[0].Text != "edited message" {
+		t.Fatalf("expected message text to be updated, got %q", result.messages[0].Text)
+	}
+}
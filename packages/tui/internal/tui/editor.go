@@ -0,0 +1,55 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea/v2"
+
+// Editor is the input widget the Model drives: it owns the prompt buffer,
+// its own debounce/submit semantics, and the handful of editing gestures
+// (clear, paste, newline, bash-mode) bound to it.
+type Editor interface {
+	tea.Model
+
+	Content() string
+	Cursor() *tea.Cursor
+	Lines() int
+	Value() string
+	Length() int
+
+	Focused() bool
+	Focus() (tea.Model, tea.Cmd)
+	Blur()
+
+	Submit() (tea.Model, tea.Cmd)
+	SubmitBash() (tea.Model, tea.Cmd)
+	Clear() (tea.Model, tea.Cmd)
+	Paste() (tea.Model, tea.Cmd)
+	Newline() (tea.Model, tea.Cmd)
+
+	SetValue(value string)
+	SetValueWithAttachments(value string)
+
+	SetInterruptKeyInDebounce(in bool)
+	SetExitKeyInDebounce(in bool)
+
+	RestoreFromHistory(index int)
+
+	// Suspend flushes any unsaved draft to history before the process is
+	// stopped (Ctrl+Z). Resume restores focus and redraws after SIGCONT.
+	Suspend()
+	Resume()
+
+	// LastUserMessage returns the text of the most recently sent user
+	// message, used to re-send it on retry.
+	LastUserMessage() string
+
+	// SetRunMode tells the session runner whether the next submit starts
+	// a new turn, retries the previous one, or continues it.
+	SetRunMode(mode RunMode)
+
+	// AddAttachment stages the file at path to be sent alongside the next
+	// submitted prompt.
+	AddAttachment(path string) error
+	// RemoveAttachment unstages the attachment at index.
+	RemoveAttachment(index int)
+	// Attachments lists the attachments staged for the next submit.
+	Attachments() []Attachment
+}
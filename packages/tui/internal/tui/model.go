@@ -0,0 +1,123 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/commands"
+)
+
+// Model is the root bubbletea model for the TUI: it owns the editor widget
+// and routes dispatched commands to the behavior they trigger.
+type Model struct {
+	width, height int
+
+	editor Editor
+
+	// pendingSubmit arms on the first InputSubmitCommand, whatever the
+	// editor holds, and is consumed (submitting) by the next
+	// InputSubmitCommand. This gives a double-press submit debounce
+	// so an accidental single keypress doesn't send a half-typed message.
+	pendingSubmit bool
+
+	messages []Message
+	// selectedMessage is the index into messages highlighted in the
+	// message viewport, or nil when the editor has focus instead.
+	selectedMessage *int
+
+	// lastAttachError is the error, if any, from the most recent attach
+	// attempt, surfaced beneath the attachments list.
+	lastAttachError error
+}
+
+// Message is one turn of the conversation shown in the message viewport.
+type Message struct {
+	Role string
+	Text string
+}
+
+// NewModel constructs the root TUI model around the given editor.
+func NewModel(editor Editor) Model {
+	return Model{editor: editor}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.editor.Init()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case msgTempfileEditorClosed:
+		return m.handleTempfileEditorClosed(msg)
+	case tea.ResumeMsg:
+		return m.handleResume()
+	}
+
+	next, cmd := m.editor.Update(msg)
+	m.editor = next.(Editor)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	view := m.editor.View()
+	if attachments := m.editor.Attachments(); len(attachments) > 0 {
+		view += "\n" + renderAttachments(attachments)
+	}
+	if m.lastAttachError != nil {
+		view += "\n" + m.lastAttachError.Error()
+	}
+	return view
+}
+
+// executeCommand dispatches a named command to the behavior it triggers,
+// returning the updated model and any follow-up tea.Cmd.
+func (m Model) executeCommand(cmd commands.Command) (tea.Model, tea.Cmd) {
+	switch cmd.Name {
+	case commands.InputSubmitCommand:
+		return m.executeInputSubmit()
+	case commands.EditInEditorCommand:
+		return m.executeEditInEditor()
+	case commands.AppSuspendCommand:
+		return m.executeAppSuspend()
+	case commands.RetryCommand:
+		return m.executeRetry()
+	case commands.ContinueCommand:
+		return m.executeContinue()
+	case commands.AttachFileCommand:
+		return m.executeAttachFile(cmd)
+	case commands.AttachClipboardImageCommand:
+		return m.executeAttachClipboardImage()
+	case commands.RemoveAttachmentCommand:
+		return m.executeRemoveAttachment(cmd)
+	}
+	return m, nil
+}
+
+// executeAppSuspend flushes the editor's draft and stops the process; the
+// terminal is restored to the shell until SIGCONT delivers a tea.ResumeMsg.
+func (m Model) executeAppSuspend() (tea.Model, tea.Cmd) {
+	m.editor.Suspend()
+	return m, tea.Suspend
+}
+
+// handleResume re-syncs state after SIGCONT: it restores editor focus and
+// clears pendingSubmit so a Ctrl+Z mid-debounce can't fire a stale submit
+// once the process comes back to the foreground.
+func (m Model) handleResume() (tea.Model, tea.Cmd) {
+	m.pendingSubmit = false
+	m.editor.Resume()
+	return m, nil
+}
+
+func (m Model) executeInputSubmit() (tea.Model, tea.Cmd) {
+	if !m.pendingSubmit {
+		m.pendingSubmit = true
+		return m, nil
+	}
+
+	m.pendingSubmit = false
+	next, cmd := m.editor.Submit()
+	m.editor = next.(Editor)
+	return m, cmd
+}
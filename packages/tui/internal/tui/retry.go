@@ -0,0 +1,39 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea/v2"
+
+// RunMode tells the session runner whether a submit starts a new assistant
+// turn, retries the previous one, or continues streaming into it.
+type RunMode int
+
+const (
+	RunModeNew RunMode = iota
+	RunModeRetry
+	RunModeContinue
+)
+
+// executeRetry drops the last assistant reply, if any, and re-sends the
+// last user message as a new turn. Unlike InputSubmitCommand this fires
+// immediately; a retry is an explicit, already-confirmed action.
+func (m Model) executeRetry() (tea.Model, tea.Cmd) {
+	if n := len(m.messages); n > 0 && m.messages[n-1].Role == "assistant" {
+		m.messages = m.messages[:n-1]
+		m.selectedMessage = nil
+	}
+
+	m.editor.SetRunMode(RunModeRetry)
+	m.editor.SetValue(m.editor.LastUserMessage())
+	next, cmd := m.editor.Submit()
+	m.editor = next.(Editor)
+	return m, cmd
+}
+
+// executeContinue asks the provider to keep streaming into the existing
+// assistant message. It does not touch m.messages: the continuation is a
+// synthetic instruction sent to the provider, not a new transcript entry.
+func (m Model) executeContinue() (tea.Model, tea.Cmd) {
+	m.editor.SetRunMode(RunModeContinue)
+	next, cmd := m.editor.Submit()
+	m.editor = next.(Editor)
+	return m, cmd
+}
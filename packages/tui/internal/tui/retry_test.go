@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/sst/opencode/internal/commands"
+)
+
+func TestRetryFiresOncePerPressAndDropsLastAssistantReply(t *testing.T) {
+	stub := &submitStubEditor{lastUserMessage: "fix the bug"}
+	model := Model{
+		editor: stub,
+		messages: []Message{
+			{Role: "user", Text: "fix the bug"},
+			{Role: "assistant", Text: "here is a broken fix"},
+		},
+	}
+
+	command := commands.Command{Name: commands.RetryCommand}
+
+	next, _ := model.executeCommand(command)
+	result := next.(Model)
+
+	if stub.submit != 1 {
+		t.Fatalf("expected submit to be called once, got %d", stub.submit)
+	}
+	if stub.runMode != RunModeRetry {
+		t.Fatalf("expected run mode RunModeRetry, got %v", stub.runMode)
+	}
+	if stub.value != "fix the bug" {
+		t.Fatalf("expected retry to resend LastUserMessage() via SetValue, got %q", stub.value)
+	}
+	if len(result.messages) != 1 || result.messages[0].Role != "user" {
+		t.Fatalf("expected previous assistant reply to be dropped, got %+v", result.messages)
+	}
+}
+
+func TestRetryClearsSelectedMessageWhenItTruncatesMessages(t *testing.T) {
+	stub := &submitStubEditor{lastUserMessage: "fix the bug"}
+	selected := 1
+	model := Model{
+		editor: stub,
+		messages: []Message{
+			{Role: "user", Text: "fix the bug"},
+			{Role: "assistant", Text: "here is a broken fix"},
+		},
+		selectedMessage: &selected,
+	}
+
+	next, _ := model.executeCommand(commands.Command{Name: commands.RetryCommand})
+	result := next.(Model)
+
+	if result.selectedMessage != nil {
+		t.Fatalf("expected selectedMessage to be cleared after retry truncates messages, got %v", *result.selectedMessage)
+	}
+
+	// Regression: a stale selectedMessage pointing past the truncated
+	// slice must not make a later command index out of range.
+	next, _ = result.executeCommand(commands.Command{Name: commands.EditInEditorCommand})
+	_ = next.(Model)
+}
+
+func TestContinueDoesNotCreateNewMessageNode(t *testing.T) {
+	stub := &submitStubEditor{}
+	model := Model{
+		editor: stub,
+		messages: []Message{
+			{Role: "user", Text: "tell me more"},
+			{Role: "assistant", Text: "partial answer"},
+		},
+	}
+
+	command := commands.Command{Name: commands.ContinueCommand}
+
+	next, _ := model.executeCommand(command)
+	result := next.(Model)
+
+	if stub.submit != 1 {
+		t.Fatalf("expected submit to be called once, got %d", stub.submit)
+	}
+	if stub.runMode != RunModeContinue {
+		t.Fatalf("expected run mode RunModeContinue, got %v", stub.runMode)
+	}
+	if len(result.messages) != 2 {
+		t.Fatalf("expected continue not to add a message node, got %d messages", len(result.messages))
+	}
+}
@@ -8,8 +8,20 @@ import (
 )
 
 type submitStubEditor struct {
-	submit int
+	submit  int
 	newline int
+
+	value                   string
+	setValueWithAttachments string
+
+	suspendCalls int
+	resumeCalls  int
+
+	lastUserMessage string
+	runMode         RunMode
+
+	attachments  []Attachment
+	addAttachErr error
 }
 
 func (s *submitStubEditor) Init() tea.Cmd {
@@ -37,7 +49,7 @@ func (s *submitStubEditor) Lines() int {
 }
 
 func (s *submitStubEditor) Value() string {
-	return ""
+	return s.value
 }
 
 func (s *submitStubEditor) Length() int {
@@ -56,6 +68,7 @@ func (s *submitStubEditor) Blur() {}
 
 func (s *submitStubEditor) Submit() (tea.Model, tea.Cmd) {
 	s.submit++
+	s.attachments = nil
 	return s, func() tea.Msg { return nil }
 }
 
@@ -76,9 +89,11 @@ func (s *submitStubEditor) Newline() (tea.Model, tea.Cmd) {
 	return s, nil
 }
 
-func (s *submitStubEditor) SetValue(value string) {}
+func (s *submitStubEditor) SetValue(value string) { s.value = value }
 
-func (s *submitStubEditor) SetValueWithAttachments(value string) {}
+func (s *submitStubEditor) SetValueWithAttachments(value string) {
+	s.setValueWithAttachments = value
+}
 
 func (s *submitStubEditor) SetInterruptKeyInDebounce(in bool) {}
 
@@ -86,6 +101,33 @@ func (s *submitStubEditor) SetExitKeyInDebounce(in bool) {}
 
 func (s *submitStubEditor) RestoreFromHistory(index int) {}
 
+func (s *submitStubEditor) Suspend() { s.suspendCalls++ }
+
+func (s *submitStubEditor) Resume() { s.resumeCalls++ }
+
+func (s *submitStubEditor) LastUserMessage() string { return s.lastUserMessage }
+
+func (s *submitStubEditor) SetRunMode(mode RunMode) { s.runMode = mode }
+
+func (s *submitStubEditor) AddAttachment(path string) error {
+	if s.addAttachErr != nil {
+		return s.addAttachErr
+	}
+	s.attachments = append(s.attachments, Attachment{Path: path})
+	return nil
+}
+
+func (s *submitStubEditor) RemoveAttachment(index int) {
+	if index < 0 || index >= len(s.attachments) {
+		return
+	}
+	s.attachments = append(s.attachments[:index], s.attachments[index+1:]...)
+}
+
+func (s *submitStubEditor) Attachments() []Attachment {
+	return s.attachments
+}
+
 func TestInputSubmitDebounceDoublePress(t *testing.T) {
 	stub := &submitStubEditor{}
 	model := Model{
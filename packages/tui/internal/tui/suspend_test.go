@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/commands"
+)
+
+func TestAppSuspendCommandFlushesAndSuspends(t *testing.T) {
+	stub := &submitStubEditor{}
+	model := Model{editor: stub}
+
+	command := commands.Command{Name: commands.AppSuspendCommand}
+
+	next, cmd := model.executeCommand(command)
+	_ = next.(Model)
+
+	if stub.suspendCalls != 1 {
+		t.Fatalf("expected Suspend to be called once, got %d", stub.suspendCalls)
+	}
+	if cmd == nil {
+		t.Fatal("expected a non-nil tea.Suspend command")
+	}
+}
+
+func TestResumeClearsPendingSubmit(t *testing.T) {
+	stub := &submitStubEditor{}
+	model := Model{editor: stub, pendingSubmit: true}
+
+	next, _ := model.Update(tea.ResumeMsg{})
+	result := next.(Model)
+
+	if result.pendingSubmit {
+		t.Fatal("expected pendingSubmit to be cleared on resume")
+	}
+	if stub.resumeCalls != 1 {
+		t.Fatalf("expected Resume to be called once, got %d", stub.resumeCalls)
+	}
+	if stub.submit != 0 {
+		t.Fatalf("expected resume not to trigger a submit, got %d", stub.submit)
+	}
+}